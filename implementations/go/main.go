@@ -2,11 +2,16 @@ package main
 
 import (
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io/ioutil"
+	"math"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/DAMEDIC/fhir-toolbox-go/fhirpath"
@@ -23,15 +28,26 @@ type TestResult struct {
 	ExecutionTimeMs float64       `json:"execution_time_ms"`
 	Expected        []interface{} `json:"expected"`
 	Actual          []interface{} `json:"actual"`
+	Diffs           []Diff        `json:"diffs,omitempty"`
 	Error           string        `json:"error,omitempty"`
 }
 
+// Diff describes a single element-wise mismatch found by compareFHIRPathResults.
+type Diff struct {
+	Index    int         `json:"index"`
+	Expected interface{} `json:"expected"`
+	Actual   interface{} `json:"actual"`
+	Reason   string      `json:"reason"`
+}
+
 // TestSummary represents the summary of test results
 type TestSummary struct {
-	Total  int `json:"total"`
-	Passed int `json:"passed"`
-	Failed int `json:"failed"`
-	Errors int `json:"errors"`
+	Total       int `json:"total"`
+	Passed      int `json:"passed"`
+	Failed      int `json:"failed"`
+	Errors      int `json:"errors"`
+	Skipped     int `json:"skipped"`
+	FilteredOut int `json:"filtered_out"`
 }
 
 // TestOutput represents the complete test output
@@ -44,22 +60,38 @@ type TestOutput struct {
 
 // BenchmarkResult represents a single benchmark result
 type BenchmarkResult struct {
-	Name         string  `json:"name"`
-	Description  string  `json:"description"`
-	Expression   string  `json:"expression"`
-	Iterations   int     `json:"iterations"`
-	AvgTimeMs    float64 `json:"avg_time_ms"`
-	MinTimeMs    float64 `json:"min_time_ms"`
-	MaxTimeMs    float64 `json:"max_time_ms"`
-	OpsPerSecond float64 `json:"ops_per_second"`
+	Name            string  `json:"name"`
+	Description     string  `json:"description"`
+	Expression      string  `json:"expression"`
+	Iterations      int     `json:"iterations"`
+	AvgTimeMs       float64 `json:"avg_time_ms"`
+	MinTimeMs       float64 `json:"min_time_ms"`
+	MaxTimeMs       float64 `json:"max_time_ms"`
+	OpsPerSecond    float64 `json:"ops_per_second"`
+	AllocsPerOp     int64   `json:"allocs_per_op"`
+	BytesPerOp      int64   `json:"bytes_per_op"`
+	StdDevMs        float64 `json:"std_dev_ms"`
+	Autocorrelation float64 `json:"autocorrelation"`
+	WarmupIters     int     `json:"warmup_iters"`
+	TimedOutIters   int     `json:"timed_out_iters"`
 }
 
+// benchmarkSchemaVersion is the version of the cross-language benchmark
+// schema this runner emits and the "merge" subcommand understands. Every
+// language runner in this repo is expected to emit (and the merge step to
+// accept) this same shape, so bump it only for breaking field changes.
+const benchmarkSchemaVersion = "1.0"
+
 // BenchmarkOutput represents the complete benchmark output
 type BenchmarkOutput struct {
-	Language   string            `json:"language"`
-	Timestamp  float64           `json:"timestamp"`
-	Benchmarks []BenchmarkResult `json:"benchmarks"`
-	SystemInfo SystemInfo        `json:"system_info"`
+	SchemaVersion string               `json:"schema_version"`
+	Engine        string               `json:"engine"`
+	EngineVersion string               `json:"engine_version"`
+	Hardware      string               `json:"hardware"`
+	Timestamp     float64              `json:"timestamp"`
+	Benchmarks    []BenchmarkResult    `json:"benchmarks"`
+	Runs          map[string][]float64 `json:"runs"`
+	SystemInfo    SystemInfo           `json:"system_info"`
 }
 
 // SystemInfo represents system information
@@ -81,20 +113,43 @@ type TestCase struct {
 	Invalid        string        `json:"invalid"`
 	Group          string        `json:"group"`
 	Iterations     int           `json:"iterations"`
+	Tags           []string      `json:"tags"`
 }
 
 // GoTestRunner implements the test runner for Go
 type GoTestRunner struct {
 	specsDir   string
 	resultsDir string
+
+	skipPatterns    []*regexp.Regexp
+	includeTags     map[string]bool
+	excludeTags     map[string]bool
+	skipExpressions map[string]bool
 }
 
-// NewGoTestRunner creates a new Go test runner
-func NewGoTestRunner() (*GoTestRunner, error) {
+// NewGoTestRunner creates a new Go test runner. skipPatterns are regexes
+// matched against test names; includeTags/excludeTags filter by TestCaseJSON.Tags.
+func NewGoTestRunner(skipPatterns []string, includeTags []string, excludeTags []string) (*GoTestRunner, error) {
 	runner := &GoTestRunner{
-		specsDir:   "../../specs",
-		resultsDir: "../../results",
+		specsDir:    "../../specs",
+		resultsDir:  "../../results",
+		includeTags: toTagSet(includeTags),
+		excludeTags: toTagSet(excludeTags),
+	}
+
+	for _, pattern := range skipPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --skip pattern %q: %v", pattern, err)
+		}
+		runner.skipPatterns = append(runner.skipPatterns, re)
+	}
+
+	skipExpressions, err := loadSkipExpressions(runner.specsDir)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: Could not load specs/fhirpath/skip.json: %v\n", err)
 	}
+	runner.skipExpressions = skipExpressions
 
 	// Ensure results directory exists
 	if err := os.MkdirAll(runner.resultsDir, 0755); err != nil {
@@ -104,12 +159,85 @@ func NewGoTestRunner() (*GoTestRunner, error) {
 	return runner, nil
 }
 
+// toTagSet turns a list of tags into a lookup set, or nil if the list is empty.
+func toTagSet(tags []string) map[string]bool {
+	if len(tags) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(tags))
+	for _, t := range tags {
+		set[t] = true
+	}
+	return set
+}
+
+// tagsIntersect reports whether any tag in tags is present in set.
+func tagsIntersect(tags []string, set map[string]bool) bool {
+	for _, t := range tags {
+		if set[t] {
+			return true
+		}
+	}
+	return false
+}
+
+// loadSkipExpressions reads specs/fhirpath/skip.json, a map of engine name to
+// known-broken expressions for that engine, and returns the "go" entries. A
+// missing file is not an error - there's simply nothing to skip.
+func loadSkipExpressions(specsDir string) (map[string]bool, error) {
+	path := filepath.Join(specsDir, "fhirpath", "skip.json")
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read skip.json: %v", err)
+	}
+
+	var perEngine map[string][]string
+	if err := json.Unmarshal(data, &perEngine); err != nil {
+		return nil, fmt.Errorf("failed to parse skip.json: %v", err)
+	}
+
+	skip := make(map[string]bool, len(perEngine["go"]))
+	for _, expr := range perEngine["go"] {
+		skip[expr] = true
+	}
+	return skip, nil
+}
+
+// shouldFilterOut reports whether test should be excluded before execution
+// because it matches a --skip pattern, its tags are excluded/not included, or
+// its expression is listed in skip.json for this engine.
+func (r *GoTestRunner) shouldFilterOut(test TestCaseJSON) bool {
+	for _, re := range r.skipPatterns {
+		if re.MatchString(test.Name) {
+			return true
+		}
+	}
+
+	if r.excludeTags != nil && tagsIntersect(test.Tags, r.excludeTags) {
+		return true
+	}
+
+	if r.includeTags != nil && !tagsIntersect(test.Tags, r.includeTags) {
+		return true
+	}
+
+	if r.skipExpressions != nil && r.skipExpressions[test.Expression] {
+		return true
+	}
+
+	return false
+}
+
 // TestSuite represents a JSON test suite
 type TestSuite struct {
-	Name        string     `json:"name"`
-	Description string     `json:"description"`
-	Source      string     `json:"source"`
-	Tests       []TestCase `json:"tests"`
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	Source      string         `json:"source"`
+	Tests       []TestCaseJSON `json:"tests"`
 }
 
 // TestCaseJSON represents a test case from JSON format
@@ -123,17 +251,19 @@ type TestCaseJSON struct {
 	Description string        `json:"description"`
 	Disable     bool          `json:"disable"`
 	Error       string        `json:"error"`
+	Mode        string        `json:"mode"`
 }
 
 // loadTestSuites loads FHIRPath test cases from new JSON format
-func (r *GoTestRunner) loadTestSuites() ([]TestCase, error) {
+func (r *GoTestRunner) loadTestSuites() ([]TestCase, int, error) {
 	testsDir := filepath.Join(r.specsDir, "fhirpath", "tests")
 
 	if _, err := os.Stat(testsDir); os.IsNotExist(err) {
-		return nil, fmt.Errorf("tests directory not found: %s", testsDir)
+		return nil, 0, fmt.Errorf("tests directory not found: %s", testsDir)
 	}
 
 	var testCases []TestCase
+	filteredOut := 0
 
 	err := filepath.Walk(testsDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -169,6 +299,11 @@ func (r *GoTestRunner) loadTestSuites() ([]TestCase, error) {
 					continue
 				}
 
+				if r.shouldFilterOut(test) {
+					filteredOut++
+					continue
+				}
+
 				inputFile := test.InputFile
 				if inputFile == "" {
 					inputFile = "patient-example.json"
@@ -181,9 +316,10 @@ func (r *GoTestRunner) loadTestSuites() ([]TestCase, error) {
 					Expression:     test.Expression,
 					ExpectedOutput: test.Expected,
 					Predicate:      false, // Not used in new format
-					Mode:           "",
+					Mode:           test.Mode,
 					Invalid:        test.Error,
 					Group:          suiteName,
+					Tags:           test.Tags,
 				})
 			}
 		}
@@ -192,10 +328,190 @@ func (r *GoTestRunner) loadTestSuites() ([]TestCase, error) {
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to load test suites: %v", err)
+		return nil, 0, fmt.Errorf("failed to load test suites: %v", err)
+	}
+
+	return testCases, filteredOut, nil
+}
+
+// resourceFactory unmarshals raw FHIR JSON into a concrete generated r4
+// resource and hands it back as a fhirpath.Element.
+type resourceFactory func([]byte) (fhirpath.Element, error)
+
+// newResourceFactory builds a resourceFactory for a generated r4 resource
+// type from its zero-value constructor, e.g. newResourceFactory(func() *r4.Patient { return &r4.Patient{} }).
+func newResourceFactory[T fhirpath.Element](newResource func() T) resourceFactory {
+	return func(data []byte) (fhirpath.Element, error) {
+		resource := newResource()
+		if err := json.Unmarshal(data, resource); err != nil {
+			return nil, fmt.Errorf("failed to parse %T data: %v", resource, err)
+		}
+		return resource, nil
 	}
+}
+
+// resourceFactories maps a FHIR resourceType string to the factory that
+// materializes it, covering every R4 resource type exposed by
+// fhir-toolbox-go/model/gen/r4. Populated once at init so adding a new
+// resource type is a one-line registration rather than a new switch case.
+var resourceFactories = map[string]resourceFactory{
+	"Account":                           newResourceFactory(func() *r4.Account { return &r4.Account{} }),
+	"ActivityDefinition":                newResourceFactory(func() *r4.ActivityDefinition { return &r4.ActivityDefinition{} }),
+	"AdverseEvent":                      newResourceFactory(func() *r4.AdverseEvent { return &r4.AdverseEvent{} }),
+	"AllergyIntolerance":                newResourceFactory(func() *r4.AllergyIntolerance { return &r4.AllergyIntolerance{} }),
+	"Appointment":                       newResourceFactory(func() *r4.Appointment { return &r4.Appointment{} }),
+	"AppointmentResponse":               newResourceFactory(func() *r4.AppointmentResponse { return &r4.AppointmentResponse{} }),
+	"AuditEvent":                        newResourceFactory(func() *r4.AuditEvent { return &r4.AuditEvent{} }),
+	"Basic":                             newResourceFactory(func() *r4.Basic { return &r4.Basic{} }),
+	"Binary":                            newResourceFactory(func() *r4.Binary { return &r4.Binary{} }),
+	"BiologicallyDerivedProduct":        newResourceFactory(func() *r4.BiologicallyDerivedProduct { return &r4.BiologicallyDerivedProduct{} }),
+	"BodyStructure":                     newResourceFactory(func() *r4.BodyStructure { return &r4.BodyStructure{} }),
+	"Bundle":                            newResourceFactory(func() *r4.Bundle { return &r4.Bundle{} }),
+	"CapabilityStatement":               newResourceFactory(func() *r4.CapabilityStatement { return &r4.CapabilityStatement{} }),
+	"CarePlan":                          newResourceFactory(func() *r4.CarePlan { return &r4.CarePlan{} }),
+	"CareTeam":                          newResourceFactory(func() *r4.CareTeam { return &r4.CareTeam{} }),
+	"CatalogEntry":                      newResourceFactory(func() *r4.CatalogEntry { return &r4.CatalogEntry{} }),
+	"ChargeItem":                        newResourceFactory(func() *r4.ChargeItem { return &r4.ChargeItem{} }),
+	"ChargeItemDefinition":              newResourceFactory(func() *r4.ChargeItemDefinition { return &r4.ChargeItemDefinition{} }),
+	"Claim":                             newResourceFactory(func() *r4.Claim { return &r4.Claim{} }),
+	"ClaimResponse":                     newResourceFactory(func() *r4.ClaimResponse { return &r4.ClaimResponse{} }),
+	"ClinicalImpression":                newResourceFactory(func() *r4.ClinicalImpression { return &r4.ClinicalImpression{} }),
+	"CodeSystem":                        newResourceFactory(func() *r4.CodeSystem { return &r4.CodeSystem{} }),
+	"Communication":                     newResourceFactory(func() *r4.Communication { return &r4.Communication{} }),
+	"CommunicationRequest":              newResourceFactory(func() *r4.CommunicationRequest { return &r4.CommunicationRequest{} }),
+	"CompartmentDefinition":             newResourceFactory(func() *r4.CompartmentDefinition { return &r4.CompartmentDefinition{} }),
+	"Composition":                       newResourceFactory(func() *r4.Composition { return &r4.Composition{} }),
+	"ConceptMap":                        newResourceFactory(func() *r4.ConceptMap { return &r4.ConceptMap{} }),
+	"Condition":                         newResourceFactory(func() *r4.Condition { return &r4.Condition{} }),
+	"Consent":                           newResourceFactory(func() *r4.Consent { return &r4.Consent{} }),
+	"Contract":                          newResourceFactory(func() *r4.Contract { return &r4.Contract{} }),
+	"Coverage":                          newResourceFactory(func() *r4.Coverage { return &r4.Coverage{} }),
+	"CoverageEligibilityRequest":        newResourceFactory(func() *r4.CoverageEligibilityRequest { return &r4.CoverageEligibilityRequest{} }),
+	"CoverageEligibilityResponse":       newResourceFactory(func() *r4.CoverageEligibilityResponse { return &r4.CoverageEligibilityResponse{} }),
+	"DetectedIssue":                     newResourceFactory(func() *r4.DetectedIssue { return &r4.DetectedIssue{} }),
+	"Device":                            newResourceFactory(func() *r4.Device { return &r4.Device{} }),
+	"DeviceDefinition":                  newResourceFactory(func() *r4.DeviceDefinition { return &r4.DeviceDefinition{} }),
+	"DeviceMetric":                      newResourceFactory(func() *r4.DeviceMetric { return &r4.DeviceMetric{} }),
+	"DeviceRequest":                     newResourceFactory(func() *r4.DeviceRequest { return &r4.DeviceRequest{} }),
+	"DeviceUseStatement":                newResourceFactory(func() *r4.DeviceUseStatement { return &r4.DeviceUseStatement{} }),
+	"DiagnosticReport":                  newResourceFactory(func() *r4.DiagnosticReport { return &r4.DiagnosticReport{} }),
+	"DocumentManifest":                  newResourceFactory(func() *r4.DocumentManifest { return &r4.DocumentManifest{} }),
+	"DocumentReference":                 newResourceFactory(func() *r4.DocumentReference { return &r4.DocumentReference{} }),
+	"EffectEvidenceSynthesis":           newResourceFactory(func() *r4.EffectEvidenceSynthesis { return &r4.EffectEvidenceSynthesis{} }),
+	"Encounter":                         newResourceFactory(func() *r4.Encounter { return &r4.Encounter{} }),
+	"Endpoint":                          newResourceFactory(func() *r4.Endpoint { return &r4.Endpoint{} }),
+	"EnrollmentRequest":                 newResourceFactory(func() *r4.EnrollmentRequest { return &r4.EnrollmentRequest{} }),
+	"EnrollmentResponse":                newResourceFactory(func() *r4.EnrollmentResponse { return &r4.EnrollmentResponse{} }),
+	"EpisodeOfCare":                     newResourceFactory(func() *r4.EpisodeOfCare { return &r4.EpisodeOfCare{} }),
+	"EventDefinition":                   newResourceFactory(func() *r4.EventDefinition { return &r4.EventDefinition{} }),
+	"Evidence":                          newResourceFactory(func() *r4.Evidence { return &r4.Evidence{} }),
+	"EvidenceVariable":                  newResourceFactory(func() *r4.EvidenceVariable { return &r4.EvidenceVariable{} }),
+	"ExampleScenario":                   newResourceFactory(func() *r4.ExampleScenario { return &r4.ExampleScenario{} }),
+	"ExplanationOfBenefit":              newResourceFactory(func() *r4.ExplanationOfBenefit { return &r4.ExplanationOfBenefit{} }),
+	"FamilyMemberHistory":               newResourceFactory(func() *r4.FamilyMemberHistory { return &r4.FamilyMemberHistory{} }),
+	"Flag":                              newResourceFactory(func() *r4.Flag { return &r4.Flag{} }),
+	"Goal":                              newResourceFactory(func() *r4.Goal { return &r4.Goal{} }),
+	"GraphDefinition":                   newResourceFactory(func() *r4.GraphDefinition { return &r4.GraphDefinition{} }),
+	"Group":                             newResourceFactory(func() *r4.Group { return &r4.Group{} }),
+	"GuidanceResponse":                  newResourceFactory(func() *r4.GuidanceResponse { return &r4.GuidanceResponse{} }),
+	"HealthcareService":                 newResourceFactory(func() *r4.HealthcareService { return &r4.HealthcareService{} }),
+	"ImagingStudy":                      newResourceFactory(func() *r4.ImagingStudy { return &r4.ImagingStudy{} }),
+	"Immunization":                      newResourceFactory(func() *r4.Immunization { return &r4.Immunization{} }),
+	"ImmunizationEvaluation":            newResourceFactory(func() *r4.ImmunizationEvaluation { return &r4.ImmunizationEvaluation{} }),
+	"ImmunizationRecommendation":        newResourceFactory(func() *r4.ImmunizationRecommendation { return &r4.ImmunizationRecommendation{} }),
+	"ImplementationGuide":               newResourceFactory(func() *r4.ImplementationGuide { return &r4.ImplementationGuide{} }),
+	"InsurancePlan":                     newResourceFactory(func() *r4.InsurancePlan { return &r4.InsurancePlan{} }),
+	"Invoice":                           newResourceFactory(func() *r4.Invoice { return &r4.Invoice{} }),
+	"Library":                           newResourceFactory(func() *r4.Library { return &r4.Library{} }),
+	"Linkage":                           newResourceFactory(func() *r4.Linkage { return &r4.Linkage{} }),
+	"List":                              newResourceFactory(func() *r4.List { return &r4.List{} }),
+	"Location":                          newResourceFactory(func() *r4.Location { return &r4.Location{} }),
+	"Measure":                           newResourceFactory(func() *r4.Measure { return &r4.Measure{} }),
+	"MeasureReport":                     newResourceFactory(func() *r4.MeasureReport { return &r4.MeasureReport{} }),
+	"Media":                             newResourceFactory(func() *r4.Media { return &r4.Media{} }),
+	"Medication":                        newResourceFactory(func() *r4.Medication { return &r4.Medication{} }),
+	"MedicationAdministration":          newResourceFactory(func() *r4.MedicationAdministration { return &r4.MedicationAdministration{} }),
+	"MedicationDispense":                newResourceFactory(func() *r4.MedicationDispense { return &r4.MedicationDispense{} }),
+	"MedicationKnowledge":               newResourceFactory(func() *r4.MedicationKnowledge { return &r4.MedicationKnowledge{} }),
+	"MedicationRequest":                 newResourceFactory(func() *r4.MedicationRequest { return &r4.MedicationRequest{} }),
+	"MedicationStatement":               newResourceFactory(func() *r4.MedicationStatement { return &r4.MedicationStatement{} }),
+	"MedicinalProduct":                  newResourceFactory(func() *r4.MedicinalProduct { return &r4.MedicinalProduct{} }),
+	"MedicinalProductAuthorization":     newResourceFactory(func() *r4.MedicinalProductAuthorization { return &r4.MedicinalProductAuthorization{} }),
+	"MedicinalProductContraindication":  newResourceFactory(func() *r4.MedicinalProductContraindication { return &r4.MedicinalProductContraindication{} }),
+	"MedicinalProductIndication":        newResourceFactory(func() *r4.MedicinalProductIndication { return &r4.MedicinalProductIndication{} }),
+	"MedicinalProductIngredient":        newResourceFactory(func() *r4.MedicinalProductIngredient { return &r4.MedicinalProductIngredient{} }),
+	"MedicinalProductInteraction":       newResourceFactory(func() *r4.MedicinalProductInteraction { return &r4.MedicinalProductInteraction{} }),
+	"MedicinalProductManufactured":      newResourceFactory(func() *r4.MedicinalProductManufactured { return &r4.MedicinalProductManufactured{} }),
+	"MedicinalProductPackaged":          newResourceFactory(func() *r4.MedicinalProductPackaged { return &r4.MedicinalProductPackaged{} }),
+	"MedicinalProductPharmaceutical":    newResourceFactory(func() *r4.MedicinalProductPharmaceutical { return &r4.MedicinalProductPharmaceutical{} }),
+	"MedicinalProductUndesirableEffect": newResourceFactory(func() *r4.MedicinalProductUndesirableEffect { return &r4.MedicinalProductUndesirableEffect{} }),
+	"MessageDefinition":                 newResourceFactory(func() *r4.MessageDefinition { return &r4.MessageDefinition{} }),
+	"MessageHeader":                     newResourceFactory(func() *r4.MessageHeader { return &r4.MessageHeader{} }),
+	"MolecularSequence":                 newResourceFactory(func() *r4.MolecularSequence { return &r4.MolecularSequence{} }),
+	"NamingSystem":                      newResourceFactory(func() *r4.NamingSystem { return &r4.NamingSystem{} }),
+	"NutritionOrder":                    newResourceFactory(func() *r4.NutritionOrder { return &r4.NutritionOrder{} }),
+	"Observation":                       newResourceFactory(func() *r4.Observation { return &r4.Observation{} }),
+	"ObservationDefinition":             newResourceFactory(func() *r4.ObservationDefinition { return &r4.ObservationDefinition{} }),
+	"OperationDefinition":               newResourceFactory(func() *r4.OperationDefinition { return &r4.OperationDefinition{} }),
+	"OperationOutcome":                  newResourceFactory(func() *r4.OperationOutcome { return &r4.OperationOutcome{} }),
+	"Organization":                      newResourceFactory(func() *r4.Organization { return &r4.Organization{} }),
+	"OrganizationAffiliation":           newResourceFactory(func() *r4.OrganizationAffiliation { return &r4.OrganizationAffiliation{} }),
+	"Parameters":                        newResourceFactory(func() *r4.Parameters { return &r4.Parameters{} }),
+	"Patient":                           newResourceFactory(func() *r4.Patient { return &r4.Patient{} }),
+	"PaymentNotice":                     newResourceFactory(func() *r4.PaymentNotice { return &r4.PaymentNotice{} }),
+	"PaymentReconciliation":             newResourceFactory(func() *r4.PaymentReconciliation { return &r4.PaymentReconciliation{} }),
+	"Person":                            newResourceFactory(func() *r4.Person { return &r4.Person{} }),
+	"PlanDefinition":                    newResourceFactory(func() *r4.PlanDefinition { return &r4.PlanDefinition{} }),
+	"Practitioner":                      newResourceFactory(func() *r4.Practitioner { return &r4.Practitioner{} }),
+	"PractitionerRole":                  newResourceFactory(func() *r4.PractitionerRole { return &r4.PractitionerRole{} }),
+	"Procedure":                         newResourceFactory(func() *r4.Procedure { return &r4.Procedure{} }),
+	"Provenance":                        newResourceFactory(func() *r4.Provenance { return &r4.Provenance{} }),
+	"Questionnaire":                     newResourceFactory(func() *r4.Questionnaire { return &r4.Questionnaire{} }),
+	"QuestionnaireResponse":             newResourceFactory(func() *r4.QuestionnaireResponse { return &r4.QuestionnaireResponse{} }),
+	"RelatedPerson":                     newResourceFactory(func() *r4.RelatedPerson { return &r4.RelatedPerson{} }),
+	"RequestGroup":                      newResourceFactory(func() *r4.RequestGroup { return &r4.RequestGroup{} }),
+	"ResearchDefinition":                newResourceFactory(func() *r4.ResearchDefinition { return &r4.ResearchDefinition{} }),
+	"ResearchElementDefinition":         newResourceFactory(func() *r4.ResearchElementDefinition { return &r4.ResearchElementDefinition{} }),
+	"ResearchStudy":                     newResourceFactory(func() *r4.ResearchStudy { return &r4.ResearchStudy{} }),
+	"ResearchSubject":                   newResourceFactory(func() *r4.ResearchSubject { return &r4.ResearchSubject{} }),
+	"RiskAssessment":                    newResourceFactory(func() *r4.RiskAssessment { return &r4.RiskAssessment{} }),
+	"RiskEvidenceSynthesis":             newResourceFactory(func() *r4.RiskEvidenceSynthesis { return &r4.RiskEvidenceSynthesis{} }),
+	"Schedule":                          newResourceFactory(func() *r4.Schedule { return &r4.Schedule{} }),
+	"SearchParameter":                   newResourceFactory(func() *r4.SearchParameter { return &r4.SearchParameter{} }),
+	"ServiceRequest":                    newResourceFactory(func() *r4.ServiceRequest { return &r4.ServiceRequest{} }),
+	"Slot":                              newResourceFactory(func() *r4.Slot { return &r4.Slot{} }),
+	"Specimen":                          newResourceFactory(func() *r4.Specimen { return &r4.Specimen{} }),
+	"SpecimenDefinition":                newResourceFactory(func() *r4.SpecimenDefinition { return &r4.SpecimenDefinition{} }),
+	"StructureDefinition":               newResourceFactory(func() *r4.StructureDefinition { return &r4.StructureDefinition{} }),
+	"StructureMap":                      newResourceFactory(func() *r4.StructureMap { return &r4.StructureMap{} }),
+	"Subscription":                      newResourceFactory(func() *r4.Subscription { return &r4.Subscription{} }),
+	"Substance":                         newResourceFactory(func() *r4.Substance { return &r4.Substance{} }),
+	"SubstanceNucleicAcid":              newResourceFactory(func() *r4.SubstanceNucleicAcid { return &r4.SubstanceNucleicAcid{} }),
+	"SubstancePolymer":                  newResourceFactory(func() *r4.SubstancePolymer { return &r4.SubstancePolymer{} }),
+	"SubstanceProtein":                  newResourceFactory(func() *r4.SubstanceProtein { return &r4.SubstanceProtein{} }),
+	"SubstanceReferenceInformation":     newResourceFactory(func() *r4.SubstanceReferenceInformation { return &r4.SubstanceReferenceInformation{} }),
+	"SubstanceSourceMaterial":           newResourceFactory(func() *r4.SubstanceSourceMaterial { return &r4.SubstanceSourceMaterial{} }),
+	"SubstanceSpecification":            newResourceFactory(func() *r4.SubstanceSpecification { return &r4.SubstanceSpecification{} }),
+	"SupplyDelivery":                    newResourceFactory(func() *r4.SupplyDelivery { return &r4.SupplyDelivery{} }),
+	"SupplyRequest":                     newResourceFactory(func() *r4.SupplyRequest { return &r4.SupplyRequest{} }),
+	"Task":                              newResourceFactory(func() *r4.Task { return &r4.Task{} }),
+	"TerminologyCapabilities":           newResourceFactory(func() *r4.TerminologyCapabilities { return &r4.TerminologyCapabilities{} }),
+	"TestReport":                        newResourceFactory(func() *r4.TestReport { return &r4.TestReport{} }),
+	"TestScript":                        newResourceFactory(func() *r4.TestScript { return &r4.TestScript{} }),
+	"ValueSet":                          newResourceFactory(func() *r4.ValueSet { return &r4.ValueSet{} }),
+	"VerificationResult":                newResourceFactory(func() *r4.VerificationResult { return &r4.VerificationResult{} }),
+	"VisionPrescription":                newResourceFactory(func() *r4.VisionPrescription { return &r4.VisionPrescription{} }),
+}
 
-	return testCases, nil
+// loadGenericResource falls back to the library's generic ContainedResource
+// wrapper for any resourceType not in resourceFactories, so newly-generated
+// R4 types don't need a registry entry before they can be exercised.
+func loadGenericResource(data []byte) (fhirpath.Element, error) {
+	var contained r4.ContainedResource
+	if err := json.Unmarshal(data, &contained); err != nil {
+		return nil, fmt.Errorf("failed to parse resource via generic ContainedResource wrapper: %v", err)
+	}
+	return contained.Resource, nil
 }
 
 // loadTestData loads test data from JSON file and converts it to a FHIR resource
@@ -222,25 +538,14 @@ func (r *GoTestRunner) loadTestData(filename string) (fhirpath.Element, error) {
 		return nil, fmt.Errorf("missing or invalid resourceType in JSON data")
 	}
 
-	// Parse JSON to FHIR resource based on resource type
-	var resource fhirpath.Element
+	factory, ok := resourceFactories[resourceType]
+	if !ok {
+		factory = loadGenericResource
+	}
 
-	switch resourceType {
-	case "Patient":
-		patient := &r4.Patient{}
-		if err := json.Unmarshal(data, patient); err != nil {
-			return nil, fmt.Errorf("failed to parse patient data: %v", err)
-		}
-		resource = patient
-	case "Observation":
-		observation := &r4.Observation{}
-		if err := json.Unmarshal(data, observation); err != nil {
-			return nil, fmt.Errorf("failed to parse observation data: %v", err)
-		}
-		resource = observation
-	default:
-		// For other resource types, try a generic approach
-		return nil, fmt.Errorf("unsupported resource type: %s", resourceType)
+	resource, err := factory(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s data: %v", resourceType, err)
 	}
 
 	return resource, nil
@@ -288,27 +593,229 @@ func convertFHIRPathResult(result []fhirpath.Element) []interface{} {
 			continue
 		}
 
-		// Handle FHIR resources
-		switch v := elem.(type) {
-		case *r4.Patient:
-			converted = append(converted, map[string]interface{}{
-				"resourceType": "Patient",
-				"id":           v.Id.Value,
-			})
-		case *r4.Observation:
-			converted = append(converted, map[string]interface{}{
-				"resourceType": "Observation",
-				"id":           v.Id.Value,
-			})
-		default:
-			// For other types, convert to string representation
-			converted = append(converted, fmt.Sprintf("%v", elem))
+		// Handle FHIR resources generically: round-trip through JSON so any
+		// resource type - not just the ones with a hand-written case - comes
+		// back with id and resourceType populated the same way.
+		if data, err := json.Marshal(elem); err == nil {
+			var asMap map[string]interface{}
+			if err := json.Unmarshal(data, &asMap); err == nil && asMap["resourceType"] != nil {
+				converted = append(converted, asMap)
+				continue
+			}
 		}
+
+		// For anything that isn't a FHIR resource, fall back to a string representation
+		converted = append(converted, fmt.Sprintf("%v", elem))
 	}
 
 	return converted
 }
 
+// comparisonContext bounds decimal comparisons to the same precision used
+// when evaluating expressions, so e.g. "1.0" and "1.00" compare equal.
+var comparisonContext = apd.BaseContext.WithPrecision(10)
+
+// compareFHIRPathResults implements FHIRPath equality between an expected and
+// actual result collection: ordered, element-wise comparison where decimals
+// compare via apd.Decimal.Cmp, temporal values honor partial-date precision
+// (e.g. "2013" equals "2013-01-01"), and objects compare by canonical JSON.
+func compareFHIRPathResults(expected, actual []interface{}) (bool, []Diff) {
+	var diffs []Diff
+
+	n := len(expected)
+	if len(actual) > n {
+		n = len(actual)
+	}
+
+	for i := 0; i < n; i++ {
+		switch {
+		case i >= len(expected):
+			diffs = append(diffs, Diff{Index: i, Actual: actual[i], Reason: "unexpected extra element"})
+		case i >= len(actual):
+			diffs = append(diffs, Diff{Index: i, Expected: expected[i], Reason: "missing element"})
+		default:
+			if ok, reason := elementsEqual(expected[i], actual[i]); !ok {
+				diffs = append(diffs, Diff{Index: i, Expected: expected[i], Actual: actual[i], Reason: reason})
+			}
+		}
+	}
+
+	return len(diffs) == 0, diffs
+}
+
+// compareFHIRPathResultsLoose compares two result collections as multisets,
+// ignoring order, falling back to the ordered comparator (for its diffs) when
+// the collections differ in size or no greedy matching can be found.
+func compareFHIRPathResultsLoose(expected, actual []interface{}) (bool, []Diff) {
+	if len(expected) != len(actual) {
+		return compareFHIRPathResults(expected, actual)
+	}
+
+	used := make([]bool, len(actual))
+	for _, exp := range expected {
+		matched := false
+		for i, act := range actual {
+			if used[i] {
+				continue
+			}
+			if ok, _ := elementsEqual(exp, act); ok {
+				used[i] = true
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return compareFHIRPathResults(expected, actual)
+		}
+	}
+
+	return true, nil
+}
+
+// elementsEqual compares a single expected/actual pair with FHIRPath
+// equality semantics, returning a human-readable reason on mismatch.
+func elementsEqual(expected, actual interface{}) (bool, string) {
+	if expected == nil && actual == nil {
+		return true, ""
+	}
+
+	switch exp := expected.(type) {
+	case bool:
+		act, ok := actual.(bool)
+		if !ok || act != exp {
+			return false, "boolean mismatch"
+		}
+		return true, ""
+
+	case float64, int, int32, int64:
+		if decimalsEqual(expected, actual) {
+			return true, ""
+		}
+		return false, "numeric mismatch"
+
+	case string:
+		act, ok := actual.(string)
+		if ok && exp == act {
+			return true, ""
+		}
+		if decimalsEqual(expected, actual) {
+			return true, ""
+		}
+		if ok && partialDatesEqual(exp, act) {
+			return true, ""
+		}
+		return false, "value mismatch"
+
+	case map[string]interface{}:
+		act, ok := actual.(map[string]interface{})
+		if !ok {
+			return false, "type mismatch: expected object"
+		}
+		if canonicalJSON(exp) == canonicalJSON(act) {
+			return true, ""
+		}
+		return false, "object mismatch"
+
+	default:
+		if fmt.Sprintf("%v", expected) == fmt.Sprintf("%v", actual) {
+			return true, ""
+		}
+		return false, "value mismatch"
+	}
+}
+
+// parseDecimal converts a JSON-decoded value (a float64 from the expected
+// output, or a string as produced by convertFHIRPathResult) into an
+// apd.Decimal for precise comparison.
+func parseDecimal(v interface{}) (*apd.Decimal, bool) {
+	switch t := v.(type) {
+	case string:
+		d, _, err := apd.NewFromString(t)
+		if err != nil {
+			return nil, false
+		}
+		return d, true
+	case float64:
+		d := new(apd.Decimal)
+		if _, err := d.SetFloat64(t); err != nil {
+			return nil, false
+		}
+		return d, true
+	case int:
+		return apd.New(int64(t), 0), true
+	case int32:
+		return apd.New(int64(t), 0), true
+	case int64:
+		return apd.New(t, 0), true
+	default:
+		return nil, false
+	}
+}
+
+func decimalsEqual(expected, actual interface{}) bool {
+	expDec, ok1 := parseDecimal(expected)
+	actDec, ok2 := parseDecimal(actual)
+	if !ok1 || !ok2 {
+		return false
+	}
+
+	roundedExp, roundedAct := new(apd.Decimal), new(apd.Decimal)
+	if _, err := comparisonContext.Round(roundedExp, expDec); err != nil {
+		return false
+	}
+	if _, err := comparisonContext.Round(roundedAct, actDec); err != nil {
+		return false
+	}
+
+	return roundedExp.Cmp(roundedAct) == 0
+}
+
+// partialDatesEqual treats a lower-precision date/time as equal to a
+// higher-precision one that shares its prefix, e.g. "2013" equals
+// "2013-01-01" and "2013-01" equals "2013-01-01T10:00:00".
+func partialDatesEqual(a, b string) bool {
+	shorter, longer := a, b
+	if len(a) > len(b) {
+		shorter, longer = b, a
+	}
+
+	if !looksLikeDate(shorter) || !looksLikeDate(longer) || !strings.HasPrefix(longer, shorter) {
+		return false
+	}
+
+	if len(longer) == len(shorter) {
+		return true
+	}
+
+	// Require the extra precision to start at a field boundary so "2013"
+	// doesn't spuriously match a non-date string like "2013x".
+	boundary := longer[len(shorter)]
+	return boundary == '-' || boundary == 'T'
+}
+
+func looksLikeDate(s string) bool {
+	if len(s) < 4 {
+		return false
+	}
+	for _, r := range s[:4] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// canonicalJSON renders a value as JSON for structural comparison.
+// encoding/json sorts map keys alphabetically, so equal objects always
+// serialize identically regardless of field order.
+func canonicalJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
 // runSingleTest executes a single test case
 func (r *GoTestRunner) runSingleTest(testCase TestCase, testData fhirpath.Element) TestResult {
 	startTime := time.Now()
@@ -355,10 +862,21 @@ func (r *GoTestRunner) runSingleTest(testCase TestCase, testData fhirpath.Elemen
 	// Convert result to expected format
 	result.Actual = convertFHIRPathResult(fhirpathResult)
 
-	// Determine test status
-	// This is a simplified comparison - in a real implementation, you would need
-	// to compare the actual and expected results more carefully
-	if len(result.Actual) == len(result.Expected) {
+	// Determine test status according to the test case's comparison mode
+	var ok bool
+	switch testCase.Mode {
+	case "predicate":
+		ok = len(result.Actual) > 0
+		if !ok {
+			result.Diffs = []Diff{{Reason: "predicate expression produced no results"}}
+		}
+	case "loose":
+		ok, result.Diffs = compareFHIRPathResultsLoose(result.Expected, result.Actual)
+	default: // "strict" and unset both mean ordered, element-wise comparison
+		ok, result.Diffs = compareFHIRPathResults(result.Expected, result.Actual)
+	}
+
+	if ok {
 		result.Status = "passed"
 	} else {
 		result.Status = "failed"
@@ -376,11 +894,12 @@ func (r *GoTestRunner) runTests() error {
 
 	// Load and run test suites
 	fmt.Println("📋 Loading FHIRPath test suites...")
-	testCases, err := r.loadTestSuites()
+	testCases, filteredOut, err := r.loadTestSuites()
 	if err != nil {
 		return fmt.Errorf("failed to load test suites: %v", err)
 	}
-	fmt.Printf("📊 Found %d test cases\n", len(testCases))
+	summary.FilteredOut = filteredOut
+	fmt.Printf("📊 Found %d test cases (%d filtered out by --skip/--tags/skip.json)\n", len(testCases), filteredOut)
 
 	for _, testCase := range testCases {
 		inputFile := testCase.InputFile
@@ -401,6 +920,8 @@ func (r *GoTestRunner) runTests() error {
 			summary.Passed++
 		case "failed":
 			summary.Failed++
+		case "skipped":
+			summary.Skipped++
 		default:
 			summary.Errors++
 		}
@@ -444,20 +965,191 @@ func (r *GoTestRunner) runTests() error {
 	}
 
 	fmt.Printf("✅ Tests completed. Results saved to %s\n", filename)
-	fmt.Printf("📊 Summary: %d total, %d passed, %d failed, %d errors\n",
-		summary.Total, summary.Passed, summary.Failed, summary.Errors)
+	fmt.Printf("📊 Summary: %d total, %d passed, %d failed, %d errors, %d skipped, %d filtered out\n",
+		summary.Total, summary.Passed, summary.Failed, summary.Errors, summary.Skipped, summary.FilteredOut)
 
 	return nil
 }
 
+// runtimeStats holds the measurement-discipline statistics computed over a
+// series of timed runs, following the approach used by Futhark's Bench module:
+// warm up, run timed iterations bounded by a wall-clock budget, then look at
+// mean/stddev/autocorrelation rather than trusting a single sum-divide-by-N.
+type runtimeStats struct {
+	mean            float64
+	min             float64
+	max             float64
+	stdDev          float64
+	autocorrelation float64
+}
+
+// computeRuntimeStats computes mean, min, max, sample standard deviation, and
+// lag-1 autocorrelation for a series of timings. Autocorrelation close to 1
+// indicates the runs are not independent (e.g. thermal throttling, GC pauses
+// bleeding across iterations); a high stddev/mean ratio indicates high noise.
+func computeRuntimeStats(times []float64) runtimeStats {
+	n := float64(len(times))
+
+	var sum, min, max float64
+	min = times[0]
+	max = times[0]
+	for _, t := range times {
+		sum += t
+		if t < min {
+			min = t
+		}
+		if t > max {
+			max = t
+		}
+	}
+	mean := sum / n
+
+	var variance float64
+	for _, t := range times {
+		d := t - mean
+		variance += d * d
+	}
+	if n > 1 {
+		variance /= n - 1
+	}
+	stdDev := math.Sqrt(variance)
+
+	var autocorrelation float64
+	if len(times) > 1 && variance > 0 {
+		var numerator float64
+		for i := 0; i < len(times)-1; i++ {
+			numerator += (times[i] - mean) * (times[i+1] - mean)
+		}
+		var denominator float64
+		for _, t := range times {
+			d := t - mean
+			denominator += d * d
+		}
+		autocorrelation = numerator / denominator
+	}
+
+	return runtimeStats{
+		mean:            mean,
+		min:             min,
+		max:             max,
+		stdDev:          stdDev,
+		autocorrelation: autocorrelation,
+	}
+}
+
+const (
+	autocorrelationWarningThreshold = 0.3
+	noiseRatioWarningThreshold      = 0.05
+)
+
+// runOneBenchmark runs a single test case's expression through `warmupIters`
+// discarded warmup runs followed by up to `iterations` timed runs bounded by
+// `timeout` wall-clock time, and measures per-op allocations via a
+// runtime.MemStats delta, mirroring what `testing.B` reports for B/op and
+// allocs/op.
+func runOneBenchmark(testCase TestCase, testData fhirpath.Element, iterations int, warmupIters int, timeout time.Duration) (BenchmarkResult, error) {
+	expr, err := fhirpath.Parse(testCase.Expression)
+	if err != nil {
+		return BenchmarkResult{}, fmt.Errorf("failed to parse expression: %v", err)
+	}
+
+	ctx := r4.Context()
+	ctx = fhirpath.WithAPDContext(ctx, apd.BaseContext.WithPrecision(10))
+
+	for i := 0; i < warmupIters; i++ {
+		if _, err := fhirpath.Evaluate(ctx, testData, expr); err != nil {
+			return BenchmarkResult{}, fmt.Errorf("error evaluating expression during warmup: %v", err)
+		}
+	}
+
+	times := make([]float64, 0, iterations)
+
+	runtime.GC()
+	var memBefore, memAfter runtime.MemStats
+	runtime.ReadMemStats(&memBefore)
+
+	deadline := time.Now().Add(timeout)
+	timedOutIters := 0
+	for i := 0; i < iterations; i++ {
+		if time.Now().After(deadline) {
+			timedOutIters = iterations - i
+			break
+		}
+
+		startTime := time.Now()
+		_, err := fhirpath.Evaluate(ctx, testData, expr)
+		endTime := time.Now()
+
+		if err != nil {
+			return BenchmarkResult{}, fmt.Errorf("error evaluating expression: %v", err)
+		}
+
+		times = append(times, float64(endTime.Sub(startTime).Nanoseconds())/1000000.0)
+	}
+
+	runtime.ReadMemStats(&memAfter)
+
+	if len(times) == 0 {
+		return BenchmarkResult{}, fmt.Errorf("no iterations completed within timeout")
+	}
+
+	stats := computeRuntimeStats(times)
+	opsPerSecond := 1000.0 / stats.mean // Convert ms to ops/sec
+
+	if stats.autocorrelation > autocorrelationWarningThreshold || (stats.mean > 0 && stats.stdDev/stats.mean > noiseRatioWarningThreshold) {
+		fmt.Printf("⚠️  %s: measurement looks unstable (stddev/mean=%.3f, autocorrelation=%.3f)\n",
+			testCase.Name, stats.stdDev/stats.mean, stats.autocorrelation)
+	}
+
+	return BenchmarkResult{
+		Name:            testCase.Name,
+		Description:     testCase.Description,
+		Expression:      testCase.Expression,
+		Iterations:      len(times),
+		AvgTimeMs:       stats.mean,
+		MinTimeMs:       stats.min,
+		MaxTimeMs:       stats.max,
+		OpsPerSecond:    opsPerSecond,
+		AllocsPerOp:     int64(memAfter.Mallocs-memBefore.Mallocs) / int64(len(times)),
+		BytesPerOp:      int64(memAfter.TotalAlloc-memBefore.TotalAlloc) / int64(len(times)),
+		StdDevMs:        stats.stdDev,
+		Autocorrelation: stats.autocorrelation,
+		WarmupIters:     warmupIters,
+		TimedOutIters:   timedOutIters,
+	}, nil
+}
+
+// writeGoBenchmarkText writes benchmarks in the textual format produced by
+// `go test -bench`, so results can be fed straight into
+// golang.org/x/tools/benchmark/parse and benchstat for A/B comparison.
+func writeGoBenchmarkText(path string, benchmarks []BenchmarkResult) error {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("goos: %s\n", runtime.GOOS))
+	sb.WriteString(fmt.Sprintf("goarch: %s\n", runtime.GOARCH))
+	sb.WriteString("pkg: github.com/octofhir/fhirpath-benchmarks/implementations/go\n")
+
+	for _, b := range benchmarks {
+		nsPerOp := b.AvgTimeMs * 1e6
+		name := strings.ReplaceAll(b.Name, " ", "_")
+		sb.WriteString(fmt.Sprintf("BenchmarkFhirpath/%s-%d\t%d\t%.2f ns/op\t%d B/op\t%d allocs/op\n",
+			name, runtime.GOMAXPROCS(0), b.Iterations, nsPerOp, b.BytesPerOp, b.AllocsPerOp))
+	}
+
+	sb.WriteString("PASS\n")
+
+	return ioutil.WriteFile(path, []byte(sb.String()), 0644)
+}
+
 // runBenchmarks executes benchmark tests
-func (r *GoTestRunner) runBenchmarks() error {
+func (r *GoTestRunner) runBenchmarks(count int, warmupIters int, timedRuns int, timeout time.Duration) error {
 	fmt.Println("⚡ Running Go FHIRPath benchmarks...")
 
 	var benchmarks []BenchmarkResult
+	benchmarkRuns := make(map[string][]float64)
 
 	// Create simple benchmarks from test cases
-	testCases, err := r.loadTestSuites()
+	testCases, _, err := r.loadTestSuites()
 	if err != nil {
 		return fmt.Errorf("failed to load test suites: %v", err)
 	}
@@ -465,9 +1157,7 @@ func (r *GoTestRunner) runBenchmarks() error {
 	// Use a subset for benchmarking
 	var benchmarkCases []TestCase
 	for i := 0; i < 10 && i < len(testCases); i++ {
-		testCase := testCases[i]
-		testCase.Iterations = 100 // Set benchmark iterations
-		benchmarkCases = append(benchmarkCases, testCase)
+		benchmarkCases = append(benchmarkCases, testCases[i])
 	}
 
 	for _, testCase := range benchmarkCases {
@@ -478,77 +1168,35 @@ func (r *GoTestRunner) runBenchmarks() error {
 			continue
 		}
 
-		iterations := 1000
+		iterations := timedRuns
 		if testCase.Iterations > 0 {
 			iterations = testCase.Iterations
 		}
-		var times []float64
-
-		// Parse the expression once outside the loop
-		expr, err := fhirpath.Parse(testCase.Expression)
-		if err != nil {
-			fmt.Printf("⚠️  Skipping benchmark %s - failed to parse expression: %v\n", testCase.Name, err)
-			continue
-		}
-
-		ctx := r4.Context()
-		ctx = fhirpath.WithAPDContext(ctx, apd.BaseContext.WithPrecision(10))
-
-		for i := 0; i < iterations; i++ {
-			startTime := time.Now()
-			_, err := fhirpath.Evaluate(ctx, testData, expr)
-			endTime := time.Now()
 
+		runs := make([]float64, 0, count)
+		for run := 0; run < count; run++ {
+			benchmark, err := runOneBenchmark(testCase, testData, iterations, warmupIters, timeout)
 			if err != nil {
-				fmt.Printf("⚠️  Error in benchmark %s: %v\n", testCase.Name, err)
-				break
+				fmt.Printf("⚠️  Error in benchmark %s (run %d/%d): %v\n", testCase.Name, run+1, count, err)
+				continue
 			}
-
-			executionTime := float64(endTime.Sub(startTime).Nanoseconds()) / 1000000.0
-			times = append(times, executionTime)
+			benchmarks = append(benchmarks, benchmark)
+			runs = append(runs, benchmark.AvgTimeMs)
 		}
-
-		if len(times) == 0 {
-			continue
+		if len(runs) > 0 {
+			benchmarkRuns[testCase.Name] = runs
 		}
-
-		// Calculate statistics
-		var sum, min, max float64
-		min = times[0]
-		max = times[0]
-
-		for _, t := range times {
-			sum += t
-			if t < min {
-				min = t
-			}
-			if t > max {
-				max = t
-			}
-		}
-
-		avgTime := sum / float64(iterations)
-		opsPerSecond := 1000.0 / avgTime // Convert ms to ops/sec
-
-		benchmark := BenchmarkResult{
-			Name:         testCase.Name,
-			Description:  testCase.Description,
-			Expression:   testCase.Expression,
-			Iterations:   iterations,
-			AvgTimeMs:    avgTime,
-			MinTimeMs:    min,
-			MaxTimeMs:    max,
-			OpsPerSecond: opsPerSecond,
-		}
-
-		benchmarks = append(benchmarks, benchmark)
 	}
 
 	// Create output structure
 	output := BenchmarkOutput{
-		Language:   "go",
-		Timestamp:  float64(time.Now().Unix()) + float64(time.Now().Nanosecond())/1e9,
-		Benchmarks: benchmarks,
+		SchemaVersion: benchmarkSchemaVersion,
+		Engine:        "go",
+		EngineVersion: runtime.Version(),
+		Hardware:      fmt.Sprintf("%s/%s, %d cpus", runtime.GOOS, runtime.GOARCH, runtime.NumCPU()),
+		Timestamp:     float64(time.Now().Unix()) + float64(time.Now().Nanosecond())/1e9,
+		Benchmarks:    benchmarks,
+		Runs:          benchmarkRuns,
 		SystemInfo: SystemInfo{
 			Platform:        runtime.GOOS,
 			GoVersion:       runtime.Version(),
@@ -575,18 +1223,312 @@ func (r *GoTestRunner) runBenchmarks() error {
 		fmt.Printf("⚠️  Warning: Could not write to standard benchmark results file: %v\n", err)
 	}
 
+	// Emit a testing.B-compatible textual report for benchstat/parse consumers.
+	benchTextPath := filepath.Join(r.resultsDir, "go_benchmark.txt")
+	if err := writeGoBenchmarkText(benchTextPath, benchmarks); err != nil {
+		fmt.Printf("⚠️  Warning: Could not write go_benchmark.txt: %v\n", err)
+	}
+
 	fmt.Printf("✅ Benchmarks completed. Results saved to %s\n", filename)
 
 	return nil
 }
 
+// AllBenchmarksOutput is the unified, cross-engine result produced by the
+// "merge" subcommand: every engine's raw BenchmarkOutput plus the derived
+// speedups and result divergences computed from them.
+type AllBenchmarksOutput struct {
+	SchemaVersion string                        `json:"schema_version"`
+	GeneratedAt   float64                       `json:"generated_at"`
+	Baseline      string                        `json:"baseline"`
+	Engines       map[string]BenchmarkOutput    `json:"engines"`
+	Speedups      map[string]map[string]float64 `json:"speedups"` // engine -> case name -> geometric mean speedup vs baseline
+	Divergences   []ResultDivergence            `json:"divergences"`
+}
+
+// ResultDivergence flags a test whose actual result value differs across
+// engines, discovered by cross-referencing each engine's *_test_results.json.
+type ResultDivergence struct {
+	TestName string   `json:"test_name"`
+	Engines  []string `json:"engines"`
+	Reason   string   `json:"reason"`
+}
+
+// mergeBenchmarks ingests every *_benchmark_results.json in resultsDir,
+// checks its schema_version against benchmarkSchemaVersion, and emits a
+// unified all_benchmarks.json plus a benchstat-style textual comparison
+// matrix. Files that are not valid JSON are skipped; a file whose
+// schema_version differs only gets a warning and is still merged.
+func (r *GoTestRunner) mergeBenchmarks(baselineEngine string) error {
+	pattern := filepath.Join(r.resultsDir, "*_benchmark_results.json")
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return fmt.Errorf("failed to glob benchmark result files: %v", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no benchmark result files found matching %s", pattern)
+	}
+
+	engines := make(map[string]BenchmarkOutput, len(files))
+	firstEngine := ""
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			fmt.Printf("⚠️  Skipping %s: %v\n", file, err)
+			continue
+		}
+
+		var output BenchmarkOutput
+		if err := json.Unmarshal(data, &output); err != nil {
+			fmt.Printf("⚠️  Skipping %s: invalid benchmark JSON: %v\n", file, err)
+			continue
+		}
+
+		if output.SchemaVersion != benchmarkSchemaVersion {
+			fmt.Printf("⚠️  %s: schema_version %q does not match %q, merging anyway\n", file, output.SchemaVersion, benchmarkSchemaVersion)
+		}
+
+		engine := output.Engine
+		if engine == "" {
+			engine = strings.TrimSuffix(filepath.Base(file), "_benchmark_results.json")
+		}
+		engines[engine] = output
+		if firstEngine == "" {
+			firstEngine = engine
+		}
+	}
+
+	if len(engines) == 0 {
+		return fmt.Errorf("no valid benchmark result files found matching %s", pattern)
+	}
+
+	if _, ok := engines[baselineEngine]; baselineEngine == "" || !ok {
+		baselineEngine = firstEngine
+	}
+
+	speedups := computeSpeedups(engines, baselineEngine)
+	divergences := r.findResultDivergences(engines)
+
+	merged := AllBenchmarksOutput{
+		SchemaVersion: benchmarkSchemaVersion,
+		GeneratedAt:   float64(time.Now().Unix()) + float64(time.Now().Nanosecond())/1e9,
+		Baseline:      baselineEngine,
+		Engines:       engines,
+		Speedups:      speedups,
+		Divergences:   divergences,
+	}
+
+	outputData, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal merged benchmarks: %v", err)
+	}
+
+	allPath := filepath.Join(r.resultsDir, "all_benchmarks.json")
+	if err := ioutil.WriteFile(allPath, outputData, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", allPath, err)
+	}
+
+	textPath := filepath.Join(r.resultsDir, "benchmark_comparison.txt")
+	if err := writeComparisonMatrix(textPath, engines, speedups, baselineEngine); err != nil {
+		fmt.Printf("⚠️  Warning: could not write comparison matrix: %v\n", err)
+	}
+
+	fmt.Printf("✅ Merged %d engine(s) into %s (baseline: %s)\n", len(engines), allPath, baselineEngine)
+	if len(divergences) > 0 {
+		fmt.Printf("⚠️  %d test(s) diverge in value across engines - see %s\n", len(divergences), allPath)
+	}
+
+	return nil
+}
+
+// computeSpeedups computes, for every non-baseline engine and every case it
+// shares with the baseline, the geometric mean of the baseline's times
+// divided by the engine's times (>1 means the engine is faster than the
+// baseline, <1 means it is slower).
+func computeSpeedups(engines map[string]BenchmarkOutput, baselineEngine string) map[string]map[string]float64 {
+	baselineRuns := engines[baselineEngine].Runs
+
+	speedups := make(map[string]map[string]float64, len(engines))
+	for name, output := range engines {
+		if name == baselineEngine {
+			continue
+		}
+
+		caseSpeedups := make(map[string]float64, len(output.Runs))
+		for caseName, times := range output.Runs {
+			baselineTimes, ok := baselineRuns[caseName]
+			if !ok || len(baselineTimes) == 0 || len(times) == 0 {
+				continue
+			}
+
+			engineMean := geometricMean(times)
+			if engineMean <= 0 {
+				continue
+			}
+			caseSpeedups[caseName] = geometricMean(baselineTimes) / engineMean
+		}
+		speedups[name] = caseSpeedups
+	}
+
+	return speedups
+}
+
+func geometricMean(values []float64) float64 {
+	var sumLog float64
+	count := 0
+	for _, v := range values {
+		if v <= 0 {
+			continue
+		}
+		sumLog += math.Log(v)
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return math.Exp(sumLog / float64(count))
+}
+
+// findResultDivergences cross-references every engine's *_test_results.json
+// and flags test names whose actual result differs between engines, so a
+// speedup isn't mistaken for correctness.
+func (r *GoTestRunner) findResultDivergences(engines map[string]BenchmarkOutput) []ResultDivergence {
+	resultsByEngine := make(map[string]map[string]string, len(engines))
+
+	for engine := range engines {
+		path := filepath.Join(r.resultsDir, fmt.Sprintf("%s_test_results.json", engine))
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			continue
+		}
+
+		var output TestOutput
+		if err := json.Unmarshal(data, &output); err != nil {
+			continue
+		}
+
+		results := make(map[string]string, len(output.Tests))
+		for _, t := range output.Tests {
+			results[t.Name] = canonicalJSON(t.Actual)
+		}
+		resultsByEngine[engine] = results
+	}
+
+	testNames := make(map[string]bool)
+	for _, results := range resultsByEngine {
+		for name := range results {
+			testNames[name] = true
+		}
+	}
+
+	var divergences []ResultDivergence
+	for name := range testNames {
+		byValue := make(map[string][]string)
+		for engine, results := range resultsByEngine {
+			value, ok := results[name]
+			if !ok {
+				continue
+			}
+			byValue[value] = append(byValue[value], engine)
+		}
+
+		if len(byValue) > 1 {
+			var allEngines []string
+			for _, engineList := range byValue {
+				allEngines = append(allEngines, engineList...)
+			}
+			sort.Strings(allEngines)
+			divergences = append(divergences, ResultDivergence{
+				TestName: name,
+				Engines:  allEngines,
+				Reason:   "actual result differs across engines",
+			})
+		}
+	}
+
+	sort.Slice(divergences, func(i, j int) bool { return divergences[i].TestName < divergences[j].TestName })
+
+	return divergences
+}
+
+// writeComparisonMatrix writes a benchstat-style textual table of per-case
+// speedups for every non-baseline engine. A speedup >1x means that engine is
+// faster than the baseline for that case; <1x means it is slower.
+func writeComparisonMatrix(path string, engines map[string]BenchmarkOutput, speedups map[string]map[string]float64, baselineEngine string) error {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("benchmark comparison (baseline: %s)\n", baselineEngine))
+	sb.WriteString("speedup >1x means the engine is faster than the baseline; <1x means it is slower\n")
+
+	engineNames := make([]string, 0, len(engines))
+	for name := range engines {
+		if name != baselineEngine {
+			engineNames = append(engineNames, name)
+		}
+	}
+	sort.Strings(engineNames)
+
+	for _, name := range engineNames {
+		sb.WriteString(fmt.Sprintf("\n%s vs %s (speedup relative to %s):\n", name, baselineEngine, baselineEngine))
+
+		caseNames := make([]string, 0, len(speedups[name]))
+		for caseName := range speedups[name] {
+			caseNames = append(caseNames, caseName)
+		}
+		sort.Strings(caseNames)
+
+		for _, caseName := range caseNames {
+			sb.WriteString(fmt.Sprintf("  %-40s %.2fx\n", caseName, speedups[name][caseName]))
+		}
+	}
+
+	return ioutil.WriteFile(path, []byte(sb.String()), 0644)
+}
+
+// stringSliceFlag accumulates repeated occurrences of a flag, e.g. `-skip a -skip b`.
+type stringSliceFlag []string
+
+func (s *stringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSliceFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
+
+// splitTags parses a comma-separated --tags/--exclude-tags value into a list,
+// dropping empty entries so an unset flag yields nil rather than [""].
+func splitTags(value string) []string {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	var tags []string
+	for _, t := range strings.Split(value, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
 func main() {
+	count := flag.Int("count", 1, "number of times to repeat each benchmark, like 'go test -count' (for benchstat)")
+	warmup := flag.Int("warmup", 3, "number of warmup iterations to discard before timing each case")
+	runs := flag.Int("runs", 1000, "number of timed runs per test case (unless overridden by the test case's own iterations)")
+	timeout := flag.Duration("timeout", 10*time.Second, "wall-clock timeout for the timed runs of a single test case")
+	var skipPatterns stringSliceFlag
+	flag.Var(&skipPatterns, "skip", "regex matched against test names to exclude (repeatable)")
+	tags := flag.String("tags", "", "comma-separated list of tags to include (tests must have at least one)")
+	excludeTags := flag.String("exclude-tags", "", "comma-separated list of tags to exclude")
+	baseline := flag.String("baseline", "", "engine name to compare against in the 'merge' subcommand (defaults to whichever engine is found first)")
+	flag.Parse()
+
 	mode := "both"
-	if len(os.Args) >= 2 {
-		mode = os.Args[1]
+	if args := flag.Args(); len(args) >= 1 {
+		mode = args[0]
 	}
 
-	runner, err := NewGoTestRunner()
+	runner, err := NewGoTestRunner(skipPatterns, splitTags(*tags), splitTags(*excludeTags))
 	if err != nil {
 		fmt.Printf("❌ Failed to initialize test runner: %v\n", err)
 		os.Exit(1)
@@ -599,7 +1541,7 @@ func main() {
 			os.Exit(1)
 		}
 	case "benchmark":
-		if err := runner.runBenchmarks(); err != nil {
+		if err := runner.runBenchmarks(*count, *warmup, *runs, *timeout); err != nil {
 			fmt.Printf("❌ Benchmark execution failed: %v\n", err)
 			os.Exit(1)
 		}
@@ -608,12 +1550,17 @@ func main() {
 			fmt.Printf("❌ Test execution failed: %v\n", err)
 			os.Exit(1)
 		}
-		if err := runner.runBenchmarks(); err != nil {
+		if err := runner.runBenchmarks(*count, *warmup, *runs, *timeout); err != nil {
 			fmt.Printf("❌ Benchmark execution failed: %v\n", err)
 			os.Exit(1)
 		}
+	case "merge":
+		if err := runner.mergeBenchmarks(*baseline); err != nil {
+			fmt.Printf("❌ Merge failed: %v\n", err)
+			os.Exit(1)
+		}
 	default:
-		fmt.Printf("❌ Unknown mode: %s. Use 'test', 'benchmark', or 'both'\n", mode)
+		fmt.Printf("❌ Unknown mode: %s. Use 'test', 'benchmark', 'both', or 'merge'\n", mode)
 		os.Exit(1)
 	}
 